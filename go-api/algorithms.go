@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// AlgorithmResult es la respuesta genérica de cualquier ComputeAlgorithm.
+// Value va serializado como string para poder representar tanto enteros
+// pequeños como big.Int, conteos o dígitos sueltos sin perder precisión.
+type AlgorithmResult struct {
+	Value string
+	Extra map[string]string
+}
+
+// ComputeAlgorithm es la interfaz que debe implementar cualquier kernel
+// registrable en el registry de /compute?algo=.
+type ComputeAlgorithm interface {
+	Name() string
+	Compute(ctx context.Context, size int) (AlgorithmResult, error)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]ComputeAlgorithm{}
+)
+
+// RegisterAlgorithm añade un kernel al registry global. Cada kernel se
+// registra desde su propio init(), así sumar uno nuevo no toca el router
+// ni el rootHandler.
+func RegisterAlgorithm(a ComputeAlgorithm) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[a.Name()] = a
+}
+
+// lookupAlgorithm busca un kernel registrado por nombre.
+func lookupAlgorithm(name string) (ComputeAlgorithm, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	a, ok := registry[name]
+	return a, ok
+}
+
+// registeredAlgorithmNames devuelve los nombres registrados en orden
+// alfabético, usados por rootHandler para anunciar los algoritmos
+// disponibles dinámicamente.
+func registeredAlgorithmNames() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Config agrupa los parámetros configurables del servidor. Se carga desde
+// config.yaml (si existe en el directorio de trabajo) y se puede
+// sobreescribir con variables de entorno con prefijo GOAPI_, p.ej.
+// GOAPI_PORT=:9090. FIB_CACHE_SIZE se respeta sin prefijo por compatibilidad
+// con el nombre ya usado antes de introducir viper.
+type Config struct {
+	Port                    string        `mapstructure:"port"`
+	GRPCPort                string        `mapstructure:"grpc_port"`
+	ReadTimeout             time.Duration `mapstructure:"read_timeout"`
+	WriteTimeout            time.Duration `mapstructure:"write_timeout"`
+	IdleTimeout             time.Duration `mapstructure:"idle_timeout"`
+	ShutdownTimeout         time.Duration `mapstructure:"shutdown_timeout"`
+	Concurrency             int           `mapstructure:"concurrency"`
+	FibCacheSize            int           `mapstructure:"fib_cache_size"`
+	ComputeResponsePoolSize int           `mapstructure:"compute_response_pool_size"`
+	HealthResponsePoolSize  int           `mapstructure:"health_response_pool_size"`
+	LogLevel                string        `mapstructure:"log_level"`
+}
+
+// loadConfig lee config.yaml y lo mezcla con el entorno; las variables de
+// entorno siempre ganan sobre el archivo.
+func loadConfig() (*Config, error) {
+	v := viper.New()
+
+	v.SetConfigName("config")
+	v.SetConfigType("yaml")
+	v.AddConfigPath(".")
+
+	v.SetEnvPrefix("GOAPI")
+	v.AutomaticEnv()
+	if err := v.BindEnv("fib_cache_size", "FIB_CACHE_SIZE"); err != nil {
+		return nil, fmt.Errorf("error ligando FIB_CACHE_SIZE: %w", err)
+	}
+
+	v.SetDefault("port", ":8080")
+	v.SetDefault("grpc_port", ":8081")
+	v.SetDefault("read_timeout", 15*time.Second)
+	v.SetDefault("write_timeout", 15*time.Second)
+	v.SetDefault("idle_timeout", 5*time.Minute)
+	v.SetDefault("shutdown_timeout", 10*time.Second)
+	v.SetDefault("concurrency", runtime.NumCPU()*2000)
+	v.SetDefault("fib_cache_size", defaultFibCacheSize)
+	v.SetDefault("compute_response_pool_size", 100)
+	v.SetDefault("health_response_pool_size", 50)
+	v.SetDefault("log_level", "info")
+
+	if err := v.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFound) {
+			return nil, fmt.Errorf("error leyendo config.yaml: %w", err)
+		}
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("error parseando configuración: %w", err)
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+)
+
+// fibonacciBigNaive calcula F(n) de forma iterativa con big.Int; sirve como
+// referencia independiente de fibonacciBig para el test de abajo.
+func fibonacciBigNaive(n int) *big.Int {
+	if n < 0 {
+		return big.NewInt(0)
+	}
+	a, b := big.NewInt(0), big.NewInt(1)
+	for i := 0; i < n; i++ {
+		a, b = b, new(big.Int).Add(a, b)
+	}
+	return a
+}
+
+// TestFibonacciBigMatchesNaiveReference existe porque fibonacciBig reusa
+// *big.Int temporales para evitar allocar en cada paso de fast-doubling; un
+// aliasing bug ahí corrompe silenciosamente el resultado para casi todo n
+// sin que go vet o go build lo detecten.
+func TestFibonacciBigMatchesNaiveReference(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 10, 35, 91, 92, 100, 200, 1000, 5000} {
+		got := fibonacciBig(n)
+		want := fibonacciBigNaive(n)
+		if got.Cmp(want) != 0 {
+			t.Errorf("fibonacciBig(%d) = %s, want %s", n, got.String(), want.String())
+		}
+	}
+}
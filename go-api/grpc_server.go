@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"calculo-apis/go-api/pb"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+)
+
+// grpcServer implementa pb.ComputeServer reutilizando la misma lógica de
+// fibonacciOptimized que el transporte FastHTTP. No usa computeResponsePool:
+// cada llamada a Compute devuelve un pb.ComputeResponse nuevo.
+type grpcServer struct {
+	pb.UnimplementedComputeServer
+}
+
+func (s *grpcServer) Compute(ctx context.Context, req *pb.ComputeRequest) (*pb.ComputeResponse, error) {
+	start := time.Now()
+
+	size := int(req.GetSize())
+	if size < 0 || size > 50 {
+		size = 30
+	}
+
+	result, algorithm := fibonacciOptimized(size)
+	latency := time.Since(start).Milliseconds()
+
+	return &pb.ComputeResponse{
+		Result:    result,
+		Size:      int32(size),
+		LatencyMs: latency,
+		Algorithm: algorithm,
+	}, nil
+}
+
+func (s *grpcServer) Health(ctx context.Context, req *pb.HealthRequest) (*pb.HealthResponse, error) {
+	return &pb.HealthResponse{
+		Status:        "healthy",
+		Service:       "go-api",
+		TimestampUnix: time.Now().UTC().Unix(),
+	}, nil
+}
+
+// startGRPCServer levanta el servidor gRPC en el puerto indicado y bloquea
+// hasta que falle o el listener se cierre. Pensado para correr en su propia
+// goroutine junto al servidor FastHTTP.
+func startGRPCServer(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("no se pudo abrir el listener gRPC en %s: %w", addr, err)
+	}
+
+	// ForceServerCodec: compute.pb.go no sale de protoc real, así que sus
+	// mensajes no implementan proto.Message (ver pb.JSONCodec).
+	server := grpc.NewServer(grpc.ForceServerCodec(pb.JSONCodec{}))
+	pb.RegisterComputeServer(server, &grpcServer{})
+
+	log.Info().Str("addr", addr).Msg("gRPC server escuchando")
+	return server.Serve(lis)
+}
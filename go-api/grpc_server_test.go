@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"calculo-apis/go-api/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// TestGRPCComputeAndHealth dials the gRPC server end-to-end over an
+// in-memory connection and calls both RPCs. It exists specifically to catch
+// codec mismatches (e.g. hand-written pb messages that don't implement
+// proto.Message) that go build/go vet don't see but the first real RPC does.
+func TestGRPCComputeAndHealth(t *testing.T) {
+	fibCache = newBoundedLRUCache(defaultFibCacheSize, fibCacheTTL)
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(grpc.ForceServerCodec(pb.JSONCodec{}))
+	pb.RegisterComputeServer(server, &grpcServer{})
+
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	defer server.Stop()
+
+	dialer := func(context.Context, string) (net.Conn, error) { return lis.Dial() }
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(pb.JSONCodec{})),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewComputeClient(conn)
+
+	computeResp, err := client.Compute(context.Background(), &pb.ComputeRequest{Size: 10})
+	if err != nil {
+		t.Fatalf("Compute RPC failed: %v", err)
+	}
+	if computeResp.Result != 55 {
+		t.Fatalf("expected F(10)=55, got %d", computeResp.Result)
+	}
+
+	healthResp, err := client.Health(context.Background(), &pb.HealthRequest{})
+	if err != nil {
+		t.Fatalf("Health RPC failed: %v", err)
+	}
+	if healthResp.Status != "healthy" {
+		t.Fatalf("expected status healthy, got %q", healthResp.Status)
+	}
+}
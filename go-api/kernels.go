@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// --- fib-cached: envuelve fibonacciCached (memoización, n pequeño-mediano) ---
+
+type fibCachedAlgorithm struct{}
+
+func (fibCachedAlgorithm) Name() string { return "fib-cached" }
+
+func (fibCachedAlgorithm) Compute(ctx context.Context, size int) (AlgorithmResult, error) {
+	if size < 0 {
+		return AlgorithmResult{}, fmt.Errorf("size must be >= 0")
+	}
+	if size > fibonacciBigOverflowThreshold {
+		return AlgorithmResult{}, fmt.Errorf("size must be <= %d for fib-cached (int64 overflows above that); use fib-doubling or fib-matrix for larger n", fibonacciBigOverflowThreshold)
+	}
+	return AlgorithmResult{Value: fmt.Sprintf("%d", fibonacciCached(size))}, nil
+}
+
+func init() { RegisterAlgorithm(fibCachedAlgorithm{}) }
+
+// --- fib-iterative: envuelve fibonacciIterative (O(n), sin cache) ---
+
+type fibIterativeAlgorithm struct{}
+
+func (fibIterativeAlgorithm) Name() string { return "fib-iterative" }
+
+func (fibIterativeAlgorithm) Compute(ctx context.Context, size int) (AlgorithmResult, error) {
+	if size < 0 {
+		return AlgorithmResult{}, fmt.Errorf("size must be >= 0")
+	}
+	if size > fibonacciBigOverflowThreshold {
+		return AlgorithmResult{}, fmt.Errorf("size must be <= %d for fib-iterative (int64 overflows above that); use fib-doubling or fib-matrix for larger n", fibonacciBigOverflowThreshold)
+	}
+	return AlgorithmResult{Value: fmt.Sprintf("%d", fibonacciIterative(size))}, nil
+}
+
+func init() { RegisterAlgorithm(fibIterativeAlgorithm{}) }
+
+// --- fib-doubling: envuelve fibonacciBig (fast-doubling, precisión arbitraria) ---
+
+type fibDoublingAlgorithm struct{}
+
+func (fibDoublingAlgorithm) Name() string { return "fib-doubling" }
+
+func (fibDoublingAlgorithm) Compute(ctx context.Context, size int) (AlgorithmResult, error) {
+	if size < 0 {
+		return AlgorithmResult{}, fmt.Errorf("size must be >= 0")
+	}
+	return AlgorithmResult{Value: fibonacciBig(size).String()}, nil
+}
+
+func init() { RegisterAlgorithm(fibDoublingAlgorithm{}) }
+
+// --- fib-matrix: exponenciación de matriz 2x2 en O(log n) ---
+
+type fibMatrixAlgorithm struct{}
+
+func (fibMatrixAlgorithm) Name() string { return "fib-matrix" }
+
+func (fibMatrixAlgorithm) Compute(ctx context.Context, size int) (AlgorithmResult, error) {
+	if size < 0 {
+		return AlgorithmResult{}, fmt.Errorf("size must be >= 0")
+	}
+	return AlgorithmResult{Value: fibonacciMatrix(size).String()}, nil
+}
+
+func init() { RegisterAlgorithm(fibMatrixAlgorithm{}) }
+
+// bigMatrix2 es una matriz 2x2 de big.Int: [[a,b],[c,d]].
+type bigMatrix2 struct{ a, b, c, d *big.Int }
+
+func identityMatrix2() bigMatrix2 {
+	return bigMatrix2{big.NewInt(1), big.NewInt(0), big.NewInt(0), big.NewInt(1)}
+}
+
+func fibBaseMatrix2() bigMatrix2 {
+	return bigMatrix2{big.NewInt(1), big.NewInt(1), big.NewInt(1), big.NewInt(0)}
+}
+
+func mulMatrix2(x, y bigMatrix2) bigMatrix2 {
+	return bigMatrix2{
+		a: new(big.Int).Add(new(big.Int).Mul(x.a, y.a), new(big.Int).Mul(x.b, y.c)),
+		b: new(big.Int).Add(new(big.Int).Mul(x.a, y.b), new(big.Int).Mul(x.b, y.d)),
+		c: new(big.Int).Add(new(big.Int).Mul(x.c, y.a), new(big.Int).Mul(x.d, y.c)),
+		d: new(big.Int).Add(new(big.Int).Mul(x.c, y.b), new(big.Int).Mul(x.d, y.d)),
+	}
+}
+
+// fibonacciMatrix calcula F(n) elevando [[1,1],[1,0]] a la n-ésima potencia
+// por exponenciación binaria: [[1,1],[1,0]]^n = [[F(n+1),F(n)],[F(n),F(n-1)]].
+func fibonacciMatrix(n int) *big.Int {
+	result := identityMatrix2()
+	base := fibBaseMatrix2()
+
+	for e := n; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = mulMatrix2(result, base)
+		}
+		base = mulMatrix2(base, base)
+	}
+
+	return result.b
+}
+
+// --- primes-sieve: criba de Eratóstenes segmentada hasta N ---
+
+type primesSieveAlgorithm struct{}
+
+func (primesSieveAlgorithm) Name() string { return "primes-sieve" }
+
+func (primesSieveAlgorithm) Compute(ctx context.Context, size int) (AlgorithmResult, error) {
+	if size < 0 {
+		return AlgorithmResult{}, fmt.Errorf("size must be >= 0")
+	}
+
+	count, largest := segmentedSieveCount(size)
+	return AlgorithmResult{
+		Value: fmt.Sprintf("%d", count),
+		Extra: map[string]string{
+			"upper_bound":   fmt.Sprintf("%d", size),
+			"largest_prime": fmt.Sprintf("%d", largest),
+		},
+	}, nil
+}
+
+func init() { RegisterAlgorithm(primesSieveAlgorithm{}) }
+
+// segmentedSieveSize es el tamaño de cada bloque de la criba segmentada.
+const segmentedSieveSize = 32768
+
+// segmentedSieveCount cuenta los primos en [2, n] usando una criba de
+// Eratóstenes segmentada: una criba simple hasta sqrt(n) provee la base,
+// y cada bloque de [2, n] se criba contra esa base sin materializar un
+// array de tamaño n completo en memoria.
+func segmentedSieveCount(n int) (count int, largest int) {
+	if n < 2 {
+		return 0, 0
+	}
+
+	limit := int(math.Sqrt(float64(n))) + 1
+	basePrimes := simpleSieve(limit)
+
+	for low := 2; low <= n; low += segmentedSieveSize {
+		high := low + segmentedSieveSize
+		if high > n+1 {
+			high = n + 1
+		}
+
+		isComposite := make([]bool, high-low)
+		for _, p := range basePrimes {
+			start := ((low + p - 1) / p) * p
+			if start < p*p {
+				start = p * p
+			}
+			for x := start; x < high; x += p {
+				isComposite[x-low] = true
+			}
+		}
+
+		for i, composite := range isComposite {
+			if v := low + i; v >= 2 && !composite {
+				count++
+				largest = v
+			}
+		}
+	}
+
+	return count, largest
+}
+
+// simpleSieve calcula los primos en [2, limit] con la criba clásica;
+// usado para poblar la base de la criba segmentada.
+func simpleSieve(limit int) []int {
+	if limit < 2 {
+		return nil
+	}
+
+	composite := make([]bool, limit+1)
+	var primes []int
+	for i := 2; i <= limit; i++ {
+		if composite[i] {
+			continue
+		}
+		primes = append(primes, i)
+		for j := i * i; j <= limit; j += i {
+			composite[j] = true
+		}
+	}
+	return primes
+}
+
+// --- pi-bbp: extracción del dígito hexadecimal N de pi (Bailey–Borwein–Plouffe) ---
+
+type piBBPAlgorithm struct{}
+
+func (piBBPAlgorithm) Name() string { return "pi-bbp" }
+
+func (piBBPAlgorithm) Compute(ctx context.Context, size int) (AlgorithmResult, error) {
+	if size < 0 {
+		return AlgorithmResult{}, fmt.Errorf("size (digit position) must be >= 0")
+	}
+
+	digit := piBBPHexDigit(size)
+	return AlgorithmResult{
+		Value: string(digit),
+		Extra: map[string]string{"digit_position": fmt.Sprintf("%d", size)},
+	}, nil
+}
+
+func init() { RegisterAlgorithm(piBBPAlgorithm{}) }
+
+// piBBPHexDigit devuelve el dígito hexadecimal de pi en la posición d
+// (0-indexed, tras el punto) usando la fórmula BBP, sin necesidad de
+// calcular ninguno de los dígitos anteriores.
+func piBBPHexDigit(d int) byte {
+	x := 4*bbpSeries(1, d) - 2*bbpSeries(4, d) - bbpSeries(5, d) - bbpSeries(6, d)
+	x -= math.Floor(x)
+	if x < 0 {
+		x++
+	}
+
+	digit := int(x * 16)
+	if digit < 0 {
+		digit = 0
+	} else if digit > 15 {
+		digit = 15
+	}
+
+	return "0123456789abcdef"[digit]
+}
+
+// bbpSeries calcula, módulo 1, la suma parcial de la serie BBP
+// sum_k 16^(n-k) / (8k+j) para el término j de la fórmula.
+func bbpSeries(j, n int) float64 {
+	var s float64
+
+	for k := 0; k <= n; k++ {
+		r := 8*k + j
+		s += powMod16(n-k, r) / float64(r)
+		s -= math.Floor(s)
+	}
+
+	// Cola de la serie: converge rápido, 100 términos sobran para la
+	// precisión de un float64.
+	for k := n + 1; k <= n+100; k++ {
+		r := 8*k + j
+		s += math.Pow(16, float64(n-k)) / float64(r)
+	}
+
+	return s
+}
+
+// powMod16 calcula 16^e mod m por exponenciación binaria (e siempre >= 0
+// en las llamadas de bbpSeries).
+func powMod16(e, m int) float64 {
+	if m == 1 {
+		return 0
+	}
+
+	result := 1
+	base := 16 % m
+	for e > 0 {
+		if e&1 == 1 {
+			result = (result * base) % m
+		}
+		e >>= 1
+		base = (base * base) % m
+	}
+	return float64(result)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// initLogger configura zerolog para emitir logs JSON estructurados a
+// stdout, con el nivel global tomado de cfg.LogLevel (info si no es válido).
+func initLogger(levelStr string) {
+	level, err := zerolog.ParseLevel(levelStr)
+	if err != nil {
+		level = zerolog.InfoLevel
+	}
+	zerolog.SetGlobalLevel(level)
+
+	log.Logger = zerolog.New(os.Stdout).With().Timestamp().Logger()
+}
+
+// logRequest emite una línea de log JSON por request, con método, path,
+// status, latencia y IP remota; se llama una vez por request junto con
+// recordRequestMetrics.
+func logRequest(method, path string, status int, latencyMs int64, remoteIP string) {
+	log.Info().
+		Str("method", method).
+		Str("path", path).
+		Int("status", status).
+		Int64("latency_ms", latencyMs).
+		Str("remote_ip", remoteIP).
+		Msg("request")
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// lruShardCount particiona el cache en shards independientes para que los
+// goroutines que pegan a claves distintas no compitan por un único mutex.
+const lruShardCount = 16
+
+// lruEntry es el valor guardado en la lista de recencia de cada shard.
+type lruEntry struct {
+	key       int
+	value     int64
+	expiresAt time.Time
+}
+
+// lruShard agrupa el mutex, la lista de recencia (más reciente al frente) y
+// el índice key -> elemento de una partición del cache.
+type lruShard struct {
+	mu         sync.Mutex
+	ll         *list.List
+	index      map[int]*list.Element
+	maxEntries int
+	ttl        time.Duration
+}
+
+// removeElement quita un elemento de la lista y de su índice; debe
+// llamarse con shard.mu ya tomado.
+func (s *lruShard) removeElement(elem *list.Element) {
+	s.ll.Remove(elem)
+	delete(s.index, elem.Value.(*lruEntry).key)
+}
+
+// boundedLRUCache es un cache LRU concurrente, particionado en shards, con
+// capacidad máxima y TTL configurables. Reemplaza al sync.Map sin límite
+// que usaba fibCache, para no crecer sin cota cuando el registry de
+// algoritmos agrega kernels de alta cardinalidad.
+type boundedLRUCache struct {
+	shards [lruShardCount]*lruShard
+
+	hits      int64
+	misses    int64
+	evictions int64
+}
+
+// newBoundedLRUCache crea un cache con capacidad maxEntries/lruShardCount por
+// shard (mínimo 1, así que el total es múltiplo de lruShardCount y puede
+// diferir de maxEntries) y el TTL indicado; ttl <= 0 desactiva la expiración.
+func newBoundedLRUCache(maxEntries int, ttl time.Duration) *boundedLRUCache {
+	perShard := maxEntries / lruShardCount
+	if perShard < 1 {
+		perShard = 1
+	}
+
+	c := &boundedLRUCache{}
+	for i := range c.shards {
+		c.shards[i] = &lruShard{
+			ll:         list.New(),
+			index:      make(map[int]*list.Element),
+			maxEntries: perShard,
+			ttl:        ttl,
+		}
+	}
+	return c
+}
+
+func (c *boundedLRUCache) shardFor(key int) *lruShard {
+	return c.shards[uint(key)%lruShardCount]
+}
+
+// Load busca key en el cache; mueve la entrada al frente de su shard (más
+// reciente) si la encuentra y no expiró.
+func (c *boundedLRUCache) Load(key int) (int64, bool) {
+	shard := c.shardFor(key)
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	elem, ok := shard.index[key]
+	if !ok {
+		atomic.AddInt64(&c.misses, 1)
+		return 0, false
+	}
+
+	entry := elem.Value.(*lruEntry)
+	if shard.ttl > 0 && time.Now().After(entry.expiresAt) {
+		shard.removeElement(elem)
+		atomic.AddInt64(&c.evictions, 1)
+		atomic.AddInt64(&c.misses, 1)
+		return 0, false
+	}
+
+	shard.ll.MoveToFront(elem)
+	atomic.AddInt64(&c.hits, 1)
+	return entry.value, true
+}
+
+// Store guarda o actualiza key=value; si el shard ya está en su tope,
+// expulsa la entrada menos reciente.
+func (c *boundedLRUCache) Store(key int, value int64) {
+	shard := c.shardFor(key)
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	var expiresAt time.Time
+	if shard.ttl > 0 {
+		expiresAt = time.Now().Add(shard.ttl)
+	}
+
+	if elem, ok := shard.index[key]; ok {
+		entry := elem.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		shard.ll.MoveToFront(elem)
+		return
+	}
+
+	elem := shard.ll.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	shard.index[key] = elem
+
+	if shard.ll.Len() > shard.maxEntries {
+		if oldest := shard.ll.Back(); oldest != nil {
+			shard.removeElement(oldest)
+			atomic.AddInt64(&c.evictions, 1)
+		}
+	}
+}
+
+// Len devuelve el número de entradas guardadas en el cache, sumando todos los
+// shards. Incluye entradas con TTL vencido que ningún Load retiró todavía.
+func (c *boundedLRUCache) Len() int {
+	total := 0
+	for _, shard := range c.shards {
+		shard.mu.Lock()
+		total += shard.ll.Len()
+		shard.mu.Unlock()
+	}
+	return total
+}
+
+// Stats devuelve los contadores acumulados de hits, misses y evictions.
+func (c *boundedLRUCache) Stats() (hits, misses, evictions int64) {
+	return atomic.LoadInt64(&c.hits), atomic.LoadInt64(&c.misses), atomic.LoadInt64(&c.evictions)
+}
@@ -1,15 +1,22 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"flag"
 	"fmt"
+	"math/big"
+	"math/bits"
 	"net/http"
 	"os"
+	"os/signal"
 	"runtime"
 	"strconv"
-	"sync"
+	"syscall"
 	"time"
 
 	"github.com/goccy/go-json"
+	"github.com/rs/zerolog/log"
 	"github.com/valyala/fasthttp"
 )
 
@@ -22,10 +29,12 @@ func init() {
 
 // Estructuras de respuesta optimizadas
 type ComputeResponse struct {
-	Result    int64  `json:"result"`
-	Size      int    `json:"size"`
-	LatencyMs int64  `json:"latency_ms"`
-	Algorithm string `json:"algorithm"`
+	Result    int64             `json:"result"`
+	ResultBig string            `json:"result_big,omitempty"`
+	Size      int               `json:"size"`
+	LatencyMs int64             `json:"latency_ms"`
+	Algorithm string            `json:"algorithm"`
+	Extra     map[string]string `json:"extra,omitempty"`
 }
 
 type HealthResponse struct {
@@ -38,25 +47,40 @@ type APIInfo struct {
 	Message    string            `json:"message"`
 	Version    string            `json:"version"`
 	Features   []string          `json:"features"`
+	Algorithms []string          `json:"algorithms"`
 	Endpoints  map[string]string `json:"endpoints"`
 	SystemInfo map[string]int    `json:"system_info"`
 }
 
-// Pool de objetos para reducir allocaciones
+// Pool de objetos para reducir allocaciones. Se inicializan en main() con
+// los tamaños de Config, una vez cargada la configuración.
 var (
-	computeResponsePool = make(chan *ComputeResponse, 100)
-	healthResponsePool  = make(chan *HealthResponse, 50)
+	computeResponsePool chan *ComputeResponse
+	healthResponsePool  chan *HealthResponse
 )
 
-// Cache global thread-safe para Fibonacci
-var fibCache = sync.Map{}
+// defaultFibCacheSize es el tope de entradas usado cuando ni config.yaml ni
+// FIB_CACHE_SIZE fijan un valor.
+const defaultFibCacheSize = 4096
+
+// fibCacheTTL es el tiempo de vida de cada entrada del cache antes de
+// expirar, independientemente de la presión de tamaño.
+const fibCacheTTL = 30 * time.Minute
+
+// Cache global thread-safe para Fibonacci, acotado en tamaño
+// (Config.FibCacheSize / FIB_CACHE_SIZE) y con TTL para no crecer sin
+// límite cuando el registry de algoritmos agrega kernels de alta
+// cardinalidad. Se inicializa en main() una vez cargada la configuración.
+var fibCache *boundedLRUCache
 
 // Obtener objeto del pool
 func getComputeResponse() *ComputeResponse {
 	select {
 	case resp := <-computeResponsePool:
+		computeResponsePoolHits.Inc()
 		return resp
 	default:
+		computeResponsePoolMisses.Inc()
 		return &ComputeResponse{}
 	}
 }
@@ -64,9 +88,11 @@ func getComputeResponse() *ComputeResponse {
 // Devolver objeto al pool
 func putComputeResponse(resp *ComputeResponse) {
 	resp.Result = 0
+	resp.ResultBig = ""
 	resp.Size = 0
 	resp.LatencyMs = 0
 	resp.Algorithm = ""
+	resp.Extra = nil
 
 	select {
 	case computeResponsePool <- resp:
@@ -80,9 +106,9 @@ func fibonacciCached(n int) int64 {
 		return int64(n)
 	}
 
-	// Verificar cache
+	// Verificar cache (boundedLRUCache ya lleva sus propias hits/misses/evictions)
 	if cached, ok := fibCache.Load(n); ok {
-		return cached.(int64)
+		return cached
 	}
 
 	// Calcular recursivamente solo para números pequeños
@@ -107,15 +133,69 @@ func fibonacciIterative(n int) int64 {
 	return b
 }
 
-// Algoritmo Fibonacci híbrido ultra-optimizado
+// fibonacciBigOverflowThreshold es el mayor n para el que F(n) todavía cabe
+// en un int64 (F(93) > math.MaxInt64).
+const fibonacciBigOverflowThreshold = 92
+
+// fibDoublingStep avanza un par (F(k), F(k+1)) un bit de n mediante
+// fast-doubling:
+// F(2k)   = F(k)·(2·F(k+1) − F(k))
+// F(2k+1) = F(k)² + F(k+1)²
+// bitSet indica si el bit de n que se está consumiendo es 1 (F(2k+1)/F(2k+2))
+// o 0 (F(2k)/F(2k+1)). Siempre devuelve *big.Int frescos: a/b nunca deben
+// aliasear temporales que el siguiente paso va a mutar in-place.
+func fibDoublingStep(a, b *big.Int, bitSet bool) (*big.Int, *big.Int) {
+	// t1 = 2*F(k+1) - F(k)
+	t1 := new(big.Int).Lsh(b, 1)
+	t1.Sub(t1, a)
+	// fib2k = F(k) * (2*F(k+1) - F(k)) = F(2k)
+	fib2k := new(big.Int).Mul(a, t1)
+
+	// fib2k1 = F(k)^2 + F(k+1)^2 = F(2k+1)
+	aSq := new(big.Int).Mul(a, a)
+	bSq := new(big.Int).Mul(b, b)
+	fib2k1 := new(big.Int).Add(aSq, bSq)
+
+	if !bitSet {
+		return fib2k, fib2k1
+	}
+	return fib2k1, new(big.Int).Add(fib2k, fib2k1)
+}
+
+// fibonacciBig calcula F(n) con precisión arbitraria usando fast-doubling,
+// recorriendo los bits de n de más a menos significativo.
+func fibonacciBig(n int) *big.Int {
+	if n < 0 {
+		return big.NewInt(0)
+	}
+
+	a, b := big.NewInt(0), big.NewInt(1) // a = F(k), b = F(k+1)
+
+	for i := bits.Len(uint(n)); i > 0; i-- {
+		a, b = fibDoublingStep(a, b, (n>>(uint(i-1)))&1 == 1)
+	}
+
+	return a
+}
+
+// Algoritmo Fibonacci híbrido ultra-optimizado.
+//
+// Sus dos únicos llamadores (el /compute legacy y el RPC gRPC Compute) ya
+// acotan n a 0..50, muy por debajo de fibonacciBigOverflowThreshold (92), así
+// que nunca hace falta el rango donde F(n) deja de caber en int64; no hay
+// rama big-doubling aquí porque truncar con .Int64() reintroduciría el
+// mismo overflow silencioso que fib-cached/fib-iterative evitan rechazando
+// size > fibonacciBigOverflowThreshold.
+// n>92 por esta vía vuelve un resultado int64 sin sentido en vez de un
+// error: /compute/big, /compute/stream y /compute?algo=fib-doubling son los
+// caminos soportados para precisión arbitraria.
 func fibonacciOptimized(n int) (int64, string) {
 	if n <= 35 {
 		// Usar cache para números pequeños-medianos
 		return fibonacciCached(n), "cached"
-	} else {
-		// Usar iterativo para números grandes
-		return fibonacciIterative(n), "iterative"
 	}
+	// Usar iterativo para números grandes que aún caben en int64
+	return fibonacciIterative(n), "iterative"
 }
 
 // Handler para CORS optimizado
@@ -139,6 +219,12 @@ func computeHandler(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
+	algoName := string(ctx.QueryArgs().Peek("algo"))
+	if algoName != "" {
+		computeHandlerRegistry(ctx, start, algoName)
+		return
+	}
+
 	// Obtener parámetro size con parsing optimizado
 	sizeStr := string(ctx.QueryArgs().Peek("size"))
 	size := 30 // valor por defecto
@@ -182,6 +268,152 @@ func computeHandler(ctx *fasthttp.RequestCtx) {
 	ctx.SetBody(jsonData)
 }
 
+// computeHandlerRegistry despacha /compute?algo=... a través del registry de
+// ComputeAlgorithm, en vez del selector híbrido fijo de fibonacciOptimized.
+// Usa el mismo rango de size que /compute/big, ya que varios kernels
+// registrados (fib-doubling, primes-sieve, pi-bbp) trabajan con n grandes.
+func computeHandlerRegistry(ctx *fasthttp.RequestCtx, start time.Time, algoName string) {
+	algo, ok := lookupAlgorithm(algoName)
+	if !ok {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetBodyString(fmt.Sprintf(`{"error":"unknown algo %q"}`, algoName))
+		return
+	}
+
+	size, ok := parseBigSize(ctx)
+	if !ok {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetBodyString(fmt.Sprintf(`{"error":"size must be between 0 and %d"}`, maxBigSize))
+		return
+	}
+
+	algoResult, err := algo.Compute(ctx, size)
+	if err != nil {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetBodyString(fmt.Sprintf(`{"error":%q}`, err.Error()))
+		return
+	}
+
+	latency := time.Since(start).Milliseconds()
+
+	response := ComputeResponse{
+		ResultBig: algoResult.Value,
+		Size:      size,
+		LatencyMs: latency,
+		Algorithm: algo.Name(),
+		Extra:     algoResult.Extra,
+	}
+
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		ctx.SetBodyString(`{"error":"Failed to serialize response"}`)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(fasthttp.StatusOK)
+	ctx.SetBody(jsonData)
+}
+
+// maxBigSize es el límite superior de size aceptado por /compute/big y
+// /compute/stream; arriba de esto fast-doubling empieza a pesar demasiado
+// para una respuesta de benchmark.
+const maxBigSize = 100000
+
+// parseBigSize lee y valida el parámetro size compartido entre los
+// handlers de precisión arbitraria.
+func parseBigSize(ctx *fasthttp.RequestCtx) (int, bool) {
+	sizeStr := string(ctx.QueryArgs().Peek("size"))
+	size := 100 // valor por defecto
+
+	if len(sizeStr) > 0 {
+		parsedSize, err := strconv.Atoi(sizeStr)
+		if err != nil || parsedSize < 0 || parsedSize > maxBigSize {
+			return 0, false
+		}
+		size = parsedSize
+	}
+
+	return size, true
+}
+
+// Handler de Fibonacci con precisión arbitraria (math/big)
+func computeBigHandler(ctx *fasthttp.RequestCtx) {
+	start := time.Now()
+
+	enableCORS(ctx)
+	if string(ctx.Method()) == "OPTIONS" {
+		return
+	}
+
+	size, ok := parseBigSize(ctx)
+	if !ok {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetBodyString(fmt.Sprintf(`{"error":"size must be between 0 and %d"}`, maxBigSize))
+		return
+	}
+
+	result := fibonacciBig(size)
+	latency := time.Since(start).Milliseconds()
+
+	response := ComputeResponse{
+		ResultBig: result.String(),
+		Size:      size,
+		LatencyMs: latency,
+		Algorithm: "big-doubling",
+	}
+
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		ctx.SetBodyString(`{"error":"Failed to serialize response"}`)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(fasthttp.StatusOK)
+	ctx.SetBody(jsonData)
+}
+
+// Handler de Fibonacci en streaming: va reportando el progreso del
+// fast-doubling (bit actual / porcentaje) antes de cerrar con el resultado,
+// usando chunked transfer encoding vía SetBodyStreamWriter.
+func computeStreamHandler(ctx *fasthttp.RequestCtx) {
+	enableCORS(ctx)
+	if string(ctx.Method()) == "OPTIONS" {
+		return
+	}
+
+	size, ok := parseBigSize(ctx)
+	if !ok {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetBodyString(fmt.Sprintf(`{"error":"size must be between 0 and %d"}`, maxBigSize))
+		return
+	}
+
+	ctx.SetContentType("application/x-ndjson")
+	ctx.SetStatusCode(fasthttp.StatusOK)
+
+	totalBits := bits.Len(uint(size))
+	ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+		a, b := big.NewInt(0), big.NewInt(1)
+
+		for i := totalBits; i > 0; i-- {
+			a, b = fibDoublingStep(a, b, (size>>(uint(i-1)))&1 == 1)
+
+			progress := 100 * (totalBits - i + 1) / totalBits
+			fmt.Fprintf(w, `{"bit":%d,"progress":%d}`+"\n", i-1, progress)
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+
+		fmt.Fprintf(w, `{"result":%q,"size":%d,"algorithm":"big-doubling"}`+"\n", a.String(), size)
+		w.Flush()
+	})
+}
+
 // Handler de health check
 func healthHandler(ctx *fasthttp.RequestCtx) {
 	enableCORS(ctx)
@@ -214,25 +446,29 @@ func rootHandler(ctx *fasthttp.RequestCtx) {
 	}
 
 	// Obtener estadísticas de cache
-	cacheSize := 0
-	fibCache.Range(func(key, value interface{}) bool {
-		cacheSize++
-		return true
-	})
+	cacheHits, cacheMisses, cacheEvictions := fibCache.Stats()
 
 	response := APIInfo{
-		Message:  "Go FastHTTP API Benchmark - Ultra Optimized v3.0",
-		Version:  "3.0.0",
-		Features: []string{"FastHTTP", "goccy/go-json", "Object Pooling", "Hybrid Fibonacci", "Thread-Safe Cache", "GOMAXPROCS Tuned", "Zero-Copy"},
+		Message:    "Go FastHTTP API Benchmark - Ultra Optimized v3.0",
+		Version:    versionString(),
+		Features:   []string{"FastHTTP", "goccy/go-json", "Object Pooling", "Pluggable Algorithm Registry", "Bounded LRU Cache", "GOMAXPROCS Tuned", "Zero-Copy", "gRPC (:8081)", "Prometheus Metrics"},
+		Algorithms: registeredAlgorithmNames(),
 		Endpoints: map[string]string{
-			"compute": "/compute?size=30",
-			"health":  "/health",
+			"compute":        "/compute?size=30 (opcional &algo=fib-matrix|fib-doubling|primes-sieve|pi-bbp|...)",
+			"compute_big":    "/compute/big?size=1000",
+			"compute_stream": "/compute/stream?size=1000",
+			"health":         "/health",
+			"metrics":        "/metrics",
+			"grpc":           ":8081 (compute.Compute/Compute, compute.Compute/Health)",
 		},
 		SystemInfo: map[string]int{
-			"gomaxprocs": runtime.GOMAXPROCS(0),
-			"numcpu":     runtime.NumCPU(),
-			"goroutines": runtime.NumGoroutine(),
-			"cache_size": cacheSize,
+			"gomaxprocs":      runtime.GOMAXPROCS(0),
+			"numcpu":          runtime.NumCPU(),
+			"goroutines":      runtime.NumGoroutine(),
+			"cache_size":      fibCache.Len(),
+			"cache_hits":      int(cacheHits),
+			"cache_misses":    int(cacheMisses),
+			"cache_evictions": int(cacheEvictions),
 		},
 	}
 
@@ -249,13 +485,20 @@ func rootHandler(ctx *fasthttp.RequestCtx) {
 
 // Router principal
 func requestHandler(ctx *fasthttp.RequestCtx) {
+	start := time.Now()
 	path := string(ctx.Path())
 
 	switch path {
 	case "/compute":
 		computeHandler(ctx)
+	case "/compute/big":
+		computeBigHandler(ctx)
+	case "/compute/stream":
+		computeStreamHandler(ctx)
 	case "/health":
 		healthHandler(ctx)
+	case "/metrics":
+		metricsHandler(ctx)
 	case "/":
 		rootHandler(ctx)
 	default:
@@ -263,23 +506,58 @@ func requestHandler(ctx *fasthttp.RequestCtx) {
 		ctx.SetStatusCode(fasthttp.StatusNotFound)
 		ctx.SetBodyString(`{"error":"Not found"}`)
 	}
+
+	status := ctx.Response.StatusCode()
+	elapsed := time.Since(start)
+
+	recordRequestMetrics(path, status, elapsed)
+	logRequest(string(ctx.Method()), path, status, elapsed.Milliseconds(), ctx.RemoteIP().String())
 }
 
 func main() {
-	// Manejar health check desde línea de comandos
+	// loadConfig no usa el paquete flag, así que correrla antes de tocar
+	// flag.Parse() no choca con -version y nos deja usar cfg.Port en la
+	// rama -healthcheck de abajo.
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error cargando configuración: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Manejar health check desde línea de comandos, antes de tocar flag.Parse()
+	// para no chocar con -version.
 	if len(os.Args) > 1 && os.Args[1] == "-healthcheck" {
-		resp, err := http.Get("http://localhost:8080/health")
+		resp, err := http.Get(fmt.Sprintf("http://localhost%s/health", cfg.Port))
 		if err != nil || resp.StatusCode != 200 {
 			os.Exit(1)
 		}
 		os.Exit(0)
 	}
 
+	versionFlag := flag.Bool("version", false, "imprime la versión y build info, y termina")
+	flag.Parse()
+
+	if *versionFlag {
+		fmt.Println(versionString())
+		os.Exit(0)
+	}
+
+	initLogger(cfg.LogLevel)
+
+	computeResponsePool = make(chan *ComputeResponse, cfg.ComputeResponsePoolSize)
+	healthResponsePool = make(chan *HealthResponse, cfg.HealthResponsePoolSize)
+	fibCache = newBoundedLRUCache(cfg.FibCacheSize, fibCacheTTL)
+
 	// Pre-calentar cache con valores comunes
 	for i := 0; i <= 35; i++ {
 		fibonacciCached(i)
 	}
 
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU() * 2000
+	}
+
 	// Configurar servidor FastHTTP para máximo rendimiento
 	server := &fasthttp.Server{
 		Handler:                      requestHandler,
@@ -294,23 +572,54 @@ func main() {
 		DisablePreParseMultipartForm: true,
 		NoDefaultServerHeader:        true,
 		NoDefaultDate:                true,
-		Concurrency:                  runtime.NumCPU() * 2000,
+		Concurrency:                  concurrency,
 		// Optimizaciones adicionales ultra-agresivas
 		ReadBufferSize:    8192,
 		WriteBufferSize:   8192,
-		ReadTimeout:       time.Second * 15,
-		WriteTimeout:      time.Second * 15,
-		IdleTimeout:       time.Minute * 5,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
 		ReduceMemoryUsage: false, // Priorizar velocidad sobre memoria
 	}
 
-	fmt.Printf("🚀 Go FastHTTP API Ultra-Optimizada v3.0 iniciando en puerto 8080\n")
-	fmt.Printf("📊 GOMAXPROCS: %d\n", runtime.GOMAXPROCS(0))
-	fmt.Printf("🔧 FastHTTP + goccy/go-json + Object Pooling + Hybrid Fibonacci\n")
-	fmt.Printf("💾 Cache pre-calentado con Fibonacci(0-35)\n")
-	fmt.Printf("⚡ Configuración ultra-agresiva para máximo throughput\n")
+	log.Info().
+		Str("version", versionString()).
+		Int("gomaxprocs", runtime.GOMAXPROCS(0)).
+		Str("addr", cfg.Port).
+		Str("grpc_addr", cfg.GRPCPort).
+		Msg("go-api iniciando")
+
+	// El servidor gRPC corre en su propio puerto para no competir con el
+	// listener de FastHTTP; un error ahí no debe tumbar el transporte HTTP.
+	go func() {
+		if err := startGRPCServer(cfg.GRPCPort); err != nil {
+			log.Error().Err(err).Msg("gRPC server detenido")
+		}
+	}()
+
+	serveErrs := make(chan error, 1)
+	go func() {
+		serveErrs <- server.ListenAndServe(cfg.Port)
+	}()
 
-	if err := server.ListenAndServe(":8080"); err != nil {
-		panic(fmt.Sprintf("Error starting server: %v", err))
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	select {
+	case err := <-serveErrs:
+		if err != nil {
+			log.Fatal().Err(err).Msg("error starting server")
+		}
+	case <-ctx.Done():
+		log.Info().Msg("señal de apagado recibida, drenando requests en curso")
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer cancel()
+
+		if err := server.ShutdownWithContext(shutdownCtx); err != nil {
+			log.Error().Err(err).Msg("error durante el apagado elegante")
+		}
 	}
+
+	log.Info().Msg("go-api detenido")
 }
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+)
+
+// Contadores e histogramas globales. Usamos CounterVec/HistogramVec con la
+// etiqueta "path" para poder comparar endpoints sin registrar una métrica
+// por handler a mano.
+var (
+	requestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "go_api_requests_total",
+			Help: "Número total de requests, por path y código de estado.",
+		},
+		[]string{"path", "status"},
+	)
+
+	requestLatencySeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "go_api_request_latency_seconds",
+			Help: "Latencia por request en segundos, por path.",
+			// Este servidor responde en sub-milisegundo para la mayoría de
+			// los kernels; bucketeamos fino cerca de cero y dejamos colas
+			// anchas para fib-doubling/primes-sieve con size grande. Los
+			// valores observados son time.Duration.Seconds() (float64 con
+			// precisión sub-milisegundo), no milisegundos redondeados.
+			Buckets: []float64{0.00005, 0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5},
+		},
+		[]string{"path"},
+	)
+
+	// fibCache (boundedLRUCache) ya lleva sus propios contadores de
+	// hits/misses/evictions; los exponemos como GaugeFunc para no duplicar
+	// el bookkeeping en dos sitios.
+	fibCacheHitsGauge = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "go_api_fib_cache_hits_total",
+		Help: "Aciertos acumulados de fibCache.",
+	}, func() float64 {
+		hits, _, _ := fibCache.Stats()
+		return float64(hits)
+	})
+
+	fibCacheMissesGauge = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "go_api_fib_cache_misses_total",
+		Help: "Fallos acumulados de fibCache.",
+	}, func() float64 {
+		_, misses, _ := fibCache.Stats()
+		return float64(misses)
+	})
+
+	fibCacheEvictionsGauge = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "go_api_fib_cache_evictions_total",
+		Help: "Evicciones acumuladas de fibCache (tope de tamaño o TTL vencido).",
+	}, func() float64 {
+		_, _, evictions := fibCache.Stats()
+		return float64(evictions)
+	})
+
+	fibCacheSizeGauge = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "go_api_fib_cache_size",
+		Help: "Entradas guardadas en fibCache (incluye las de TTL vencido aún no retiradas).",
+	}, func() float64 { return float64(fibCache.Len()) })
+
+	computeResponsePoolHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "go_api_compute_response_pool_hits_total",
+		Help: "Objetos ComputeResponse reutilizados desde computeResponsePool.",
+	})
+
+	computeResponsePoolMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "go_api_compute_response_pool_misses_total",
+		Help: "Objetos ComputeResponse alocados porque el pool estaba vacío.",
+	})
+
+	goroutinesGauge = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "go_api_goroutines",
+		Help: "runtime.NumGoroutine() actual.",
+	}, func() float64 { return float64(runtime.NumGoroutine()) })
+
+	gcCyclesGauge = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "go_api_gc_cycles_total",
+		Help: "Número de ciclos de garbage collection completados.",
+	}, func() float64 {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		return float64(stats.NumGC)
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		requestsTotal,
+		requestLatencySeconds,
+		fibCacheHitsGauge,
+		fibCacheMissesGauge,
+		fibCacheEvictionsGauge,
+		fibCacheSizeGauge,
+		computeResponsePoolHits,
+		computeResponsePoolMisses,
+		goroutinesGauge,
+		gcCyclesGauge,
+	)
+}
+
+// recordRequestMetrics registra el contador y el histograma de latencia de
+// un request ya resuelto; se llama una vez por request desde requestHandler.
+// latency se pasa como time.Duration (no pre-redondeado a milisegundos
+// enteros) porque los buckets del histograma resuelven sub-milisegundo.
+func recordRequestMetrics(path string, status int, latency time.Duration) {
+	requestsTotal.WithLabelValues(path, strconv.Itoa(status)).Inc()
+	requestLatencySeconds.WithLabelValues(path).Observe(latency.Seconds())
+}
+
+// metricsHandler expone el registry de Prometheus en /metrics, adaptando el
+// http.Handler de promhttp al fasthttp.RequestHandler que usa el resto del
+// router.
+var metricsHandler = fasthttpadaptor.NewFastHTTPHandler(promhttp.Handler())
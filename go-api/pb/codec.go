@@ -0,0 +1,28 @@
+package pb
+
+import "encoding/json"
+
+// JSONCodec es un encoding.Codec de gRPC que serializa con encoding/json en
+// vez de protobuf binario.
+//
+// compute.pb.go no sale de protoc real (no hay toolchain de protobuf en
+// este entorno): ComputeRequest/ComputeResponse/HealthRequest/HealthResponse
+// son structs de Go planos, no implementan proto.Message. El codec "proto"
+// por defecto de grpc-go hace un type-assert a proto.Message antes de
+// marshalear, así que cualquier RPC real fallaría en tiempo de decode. El
+// servidor y el cliente deben forzar este codec explícitamente
+// (grpc.ForceServerCodec / grpc.ForceCodec) en vez de depender del nombre
+// "proto" registrado por defecto.
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (JSONCodec) Name() string {
+	return "json"
+}
@@ -0,0 +1,32 @@
+// Hand-maintained stand-in for real protoc-gen-go output from
+// proto/compute.proto — there's no protobuf toolchain in this environment
+// (see pb.JSONCodec). Keep it in sync with the .proto by hand; regenerating
+// with real protoc would overwrite this file.
+
+package pb
+
+type ComputeRequest struct {
+	Size int32 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *ComputeRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type ComputeResponse struct {
+	Result    int64  `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	Size      int32  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	LatencyMs int64  `protobuf:"varint,3,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	Algorithm string `protobuf:"bytes,4,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+}
+
+type HealthRequest struct{}
+
+type HealthResponse struct {
+	Status        string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Service       string `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	TimestampUnix int64  `protobuf:"varint,3,opt,name=timestamp_unix,json=timestampUnix,proto3" json:"timestamp_unix,omitempty"`
+}
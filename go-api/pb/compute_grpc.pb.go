@@ -0,0 +1,114 @@
+// Hand-maintained stand-in for real protoc-gen-go-grpc output from
+// proto/compute.proto — there's no protobuf toolchain in this environment
+// (see pb.JSONCodec). Keep it in sync with the .proto by hand; regenerating
+// with real protoc would overwrite this file.
+
+package pb
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+)
+
+// ComputeServer es la interfaz que debe implementar el servidor gRPC.
+type ComputeServer interface {
+	Compute(context.Context, *ComputeRequest) (*ComputeResponse, error)
+	Health(context.Context, *HealthRequest) (*HealthResponse, error)
+}
+
+// RegisterComputeServer registra la implementación en un *grpc.Server.
+func RegisterComputeServer(s *grpc.Server, srv ComputeServer) {
+	s.RegisterService(&Compute_ServiceDesc, srv)
+}
+
+// ComputeClient es el cliente generado para el servicio Compute.
+type ComputeClient interface {
+	Compute(ctx context.Context, in *ComputeRequest, opts ...grpc.CallOption) (*ComputeResponse, error)
+	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+}
+
+type computeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewComputeClient crea un ComputeClient sobre una conexión ya establecida.
+func NewComputeClient(cc grpc.ClientConnInterface) ComputeClient {
+	return &computeClient{cc}
+}
+
+func (c *computeClient) Compute(ctx context.Context, in *ComputeRequest, opts ...grpc.CallOption) (*ComputeResponse, error) {
+	out := new(ComputeResponse)
+	if err := c.cc.Invoke(ctx, "/compute.Compute/Compute", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *computeClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	if err := c.cc.Invoke(ctx, "/compute.Compute/Health", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UnimplementedComputeServer se puede embeber para forward-compatibility:
+// nuevos métodos añadidos al .proto no rompen implementaciones existentes.
+type UnimplementedComputeServer struct{}
+
+func (UnimplementedComputeServer) Compute(context.Context, *ComputeRequest) (*ComputeResponse, error) {
+	return nil, errUnimplemented("Compute")
+}
+
+func (UnimplementedComputeServer) Health(context.Context, *HealthRequest) (*HealthResponse, error) {
+	return nil, errUnimplemented("Health")
+}
+
+func errUnimplemented(method string) error {
+	return fmt.Errorf("method %s not implemented", method)
+}
+
+var Compute_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "compute.Compute",
+	HandlerType: (*ComputeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Compute",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := new(ComputeRequest)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return srv.(ComputeServer).Compute(ctx, in)
+				}
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/compute.Compute/Compute"}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(ComputeServer).Compute(ctx, req.(*ComputeRequest))
+				}
+				return interceptor(ctx, in, info, handler)
+			},
+		},
+		{
+			MethodName: "Health",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := new(HealthRequest)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return srv.(ComputeServer).Health(ctx, in)
+				}
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/compute.Compute/Health"}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(ComputeServer).Health(ctx, req.(*HealthRequest))
+				}
+				return interceptor(ctx, in, info, handler)
+			},
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/compute.proto",
+}
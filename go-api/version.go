@@ -0,0 +1,20 @@
+package main
+
+import "fmt"
+
+// buildVersion, buildGitSHA y buildTime se fijan en build time vía:
+//
+//	go build -ldflags "-X main.buildVersion=3.1.0 -X main.buildGitSHA=$(git rev-parse --short HEAD) -X main.buildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// y quedan en sus valores por defecto en builds locales sin ldflags.
+var (
+	buildVersion = "dev"
+	buildGitSHA  = "unknown"
+	buildTime    = "unknown"
+)
+
+// versionString arma la línea impresa por -version y el campo
+// APIInfo.Version que expone rootHandler.
+func versionString() string {
+	return fmt.Sprintf("%s (commit %s, built %s)", buildVersion, buildGitSHA, buildTime)
+}